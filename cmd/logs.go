@@ -16,14 +16,21 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	api "k8s.io/client-go/pkg/api/v1"
 
@@ -35,6 +42,112 @@ import (
 var containerFlag string
 var tailFlag int
 var followFlag bool
+var sinceFlag time.Duration
+var sinceTimeFlag string
+var timestampsFlag bool
+var previousFlag bool
+var utcFlag bool
+var allContainersFlag bool
+var prefixFlag bool
+var namesOnlyFlag bool
+var maxReconnectFlag int
+var maxLineBytesFlag int
+var outputFlag string
+
+const defaultMaxLineBytes = 1 << 20 // 1 MiB
+
+// multiLogWriter serializes writes from many concurrent log-streaming
+// goroutines onto a single buffered stdout, so lines from different
+// streams can never interleave mid-line.
+type multiLogWriter struct {
+	mu  sync.Mutex
+	buf *bufio.Writer
+}
+
+func newMultiLogWriter(w io.Writer) *multiLogWriter {
+	return &multiLogWriter{buf: bufio.NewWriter(w)}
+}
+
+// WriteLine atomically writes a colored prefix (if any), the payload, and a
+// trailing newline, then flushes, all under one lock.
+func (w *multiLogWriter) WriteLine(prefix string, col *color.Color, line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if prefix != "" {
+		col.Fprint(w.buf, prefix)
+	}
+	w.buf.Write(line)
+	w.buf.WriteByte('\n')
+	w.buf.Flush()
+}
+
+// WriteHeader atomically writes a single colored line, such as the
+// "Logs for pod ..." banner preceding a snapshot.
+func (w *multiLogWriter) WriteHeader(col *color.Color, text string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	col.Fprintln(w.buf, text)
+	w.buf.Flush()
+}
+
+// logsOptions bundles the pod log request and presentation settings for
+// GetMultiLogs, mirroring kubectl's own log options.
+type logsOptions struct {
+	Namespace     string
+	Container     string
+	Tail          int
+	Follow        bool
+	UseColor      bool
+	SinceSeconds  *int64
+	SinceTime     *metav1.Time
+	Timestamps    bool
+	Previous      bool
+	UTC           bool
+	AllContainers bool
+	Prefix        bool
+	NamesOnly     bool
+	MaxReconnect  int
+	MaxLineBytes  int
+	Output        string
+}
+
+// podContainer identifies a single (pod, container) log stream.
+type podContainer struct {
+	Pod       api.Pod
+	Container string
+}
+
+// streamMeta identifies the source of a log line for structured output
+// modes (--output json/logfmt) and text-mode prefixing alike.
+type streamMeta struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// resolveContainers determines which containers of pod should be streamed,
+// given the --container and --all-containers flags.
+func resolveContainers(pod api.Pod, opts logsOptions) ([]string, error) {
+	if opts.AllContainers && opts.Container == "" {
+		names := make([]string, 0, len(pod.Spec.Containers))
+		for _, c := range pod.Spec.Containers {
+			names = append(names, c.Name)
+		}
+		return names, nil
+	}
+
+	if opts.Container != "" {
+		return []string{opts.Container}, nil
+	}
+
+	if len(pod.Spec.Containers) == 1 {
+		return []string{pod.Spec.Containers[0].Name}, nil
+	}
+
+	return nil, fmt.Errorf("pod %s has multiple containers; specify --container or --all-containers", pod.Name)
+}
 
 // logsCmd represents the logs command
 var logsCmd = &cobra.Command{
@@ -46,7 +159,19 @@ Examples:
 argonaut logs "app=hello"
 
 # Return snapshot logs in the ingress container for all "app=hello" pods
-argonaut logs "app=hello" -c ingress`,
+argonaut logs "app=hello" -c ingress
+
+# Return only logs from the last 5 minutes
+argonaut logs "app=hello" --since 5m
+
+# Follow logs with timestamps, correlated across interleaved pods
+argonaut logs "app=hello" -f -T
+
+# Follow every container in every matching pod
+argonaut logs "app=hello" -f --all-containers
+
+# Emit newline-delimited JSON for downstream processing
+argonaut logs "app=hello" -o json`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) < 1 {
 			fmt.Println("Missing required argument: labelSelector")
@@ -55,6 +180,35 @@ argonaut logs "app=hello" -c ingress`,
 
 		labelSelector := args[0]
 
+		if sinceFlag != 0 && sinceTimeFlag != "" {
+			fmt.Println("--since and --since-time are mutually exclusive")
+			return
+		}
+
+		switch outputFlag {
+		case "text", "json", "logfmt":
+		default:
+			fmt.Println("--output must be one of: text, json, logfmt")
+			return
+		}
+
+		var sinceSeconds *int64
+		if sinceFlag != 0 {
+			secs := int64(math.Ceil(sinceFlag.Seconds()))
+			sinceSeconds = &secs
+		}
+
+		var sinceTime *metav1.Time
+		if sinceTimeFlag != "" {
+			parsed, err := time.Parse(time.RFC3339, sinceTimeFlag)
+			if err != nil {
+				fmt.Println("Invalid --since-time:", err)
+				return
+			}
+			t := metav1.NewTime(parsed)
+			sinceTime = &t
+		}
+
 		fmt.Printf("\nRetrieving logs...this could take a minute.\n\n")
 
 		// retrieve k8s client via .kube/config
@@ -64,7 +218,26 @@ argonaut logs "app=hello" -c ingress`,
 			return
 		}
 
-		err = GetMultiLogs(client, labelSelector, namespaceFlag, containerFlag, tailFlag, followFlag, colorFlag)
+		opts := logsOptions{
+			Namespace:     namespaceFlag,
+			Container:     containerFlag,
+			Tail:          tailFlag,
+			Follow:        followFlag,
+			UseColor:      colorFlag,
+			SinceSeconds:  sinceSeconds,
+			SinceTime:     sinceTime,
+			Timestamps:    timestampsFlag,
+			Previous:      previousFlag,
+			UTC:           utcFlag,
+			AllContainers: allContainersFlag,
+			Prefix:        prefixFlag,
+			NamesOnly:     namesOnlyFlag,
+			MaxReconnect:  maxReconnectFlag,
+			MaxLineBytes:  maxLineBytesFlag,
+			Output:        outputFlag,
+		}
+
+		err = GetMultiLogs(client, labelSelector, opts)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -74,7 +247,7 @@ argonaut logs "app=hello" -c ingress`,
 }
 
 // GetMultiLogs retrieves all logs for the given label selector
-func GetMultiLogs(client *kubernetes.Clientset, labelSelector string, namespace string, container string, tail int, follow bool, useColor bool) error {
+func GetMultiLogs(client *kubernetes.Clientset, labelSelector string, opts logsOptions) error {
 	// parse given label selector
 	selector, err := labels.Parse(labelSelector)
 	if err != nil {
@@ -82,6 +255,7 @@ func GetMultiLogs(client *kubernetes.Clientset, labelSelector string, namespace
 	}
 
 	// determine namespace to query
+	namespace := opts.Namespace
 	if namespace == "" {
 		namespace = api.NamespaceDefault
 	}
@@ -102,28 +276,60 @@ func GetMultiLogs(client *kubernetes.Clientset, labelSelector string, namespace
 		return fmt.Errorf("No pods in namespace: " + namespace)
 	}
 
-	var wg sync.WaitGroup
+	// resolve the (pod, container) pairs to stream, so that every stream
+	// gets its own color regardless of how many containers a pod has
+	var targets []podContainer
+	for _, pod := range pods.Items {
+		containers, err := resolveContainers(pod, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, container := range containers {
+			targets = append(targets, podContainer{Pod: pod, Container: container})
+		}
+	}
+
+	// --names-only just lists the matched streams, grep-friendly, without
+	// fetching any log content
+	if opts.NamesOnly {
+		color.NoColor = true
+		for _, t := range targets {
+			fmt.Printf("%s/%s\n", t.Pod.Name, t.Container)
+		}
+		return nil
+	}
+
+	// a single writer serializes output from every stream onto stdout so
+	// lines from different pods/containers can never interleave mid-line
+	out := newMultiLogWriter(os.Stdout)
+
+	// follow mode manages its own pod lifecycle (new pods appearing,
+	// existing ones disappearing), so it takes over from here
+	if opts.Follow {
+		return followLogs(client, namespace, selector, pods.Items, opts, out)
+	}
+
 	var col *color.Color
 	colorLen := len(colors)
 
-	// iterate over pods and get logs
-	for ndx, pod := range pods.Items {
-		// set pod logging options
-		podLogOpts := &api.PodLogOptions{}
-		if container != "" {
-			podLogOpts.Container = container
+	// iterate over resolved (pod, container) pairs and get snapshot logs
+	for ndx, t := range targets {
+		podLogOpts := &api.PodLogOptions{
+			Container:    t.Container,
+			SinceSeconds: opts.SinceSeconds,
+			SinceTime:    opts.SinceTime,
+			Previous:     opts.Previous,
+			Timestamps:   opts.Timestamps,
 		}
 
 		// set tail line count
-		if tail != -1 {
-			convTail := int64(tail)
+		if opts.Tail != -1 {
+			convTail := int64(opts.Tail)
 			podLogOpts.TailLines = &convTail
 		}
 
-		// defaults to false
-		podLogOpts.Follow = follow
-
-		if useColor {
+		if opts.UseColor {
 			col = colors[ndx%colorLen] // give this stream one of the set colors
 		} else {
 			color.NoColor = true           // turn off all colors
@@ -131,49 +337,507 @@ func GetMultiLogs(client *kubernetes.Clientset, labelSelector string, namespace
 		}
 
 		// get specified pod's log request and run it
-		req := podIntr.GetLogs(pod.Name, podLogOpts)
+		req := podIntr.GetLogs(t.Pod.Name, podLogOpts)
 		stream, err := req.Stream()
 		if err != nil {
 			return err
 		}
 
-		// attach to and stream logs for this container until stopped
-		if follow {
-			wg.Add(1)
-			go openLogStream(stream, pod.Name, &wg, col)
-		} else { // gather log request output and dump to stdout
-			col.Println("Logs for pod", pod.Name, ":")
+		if opts.Output == "text" && opts.Prefix {
+			out.WriteHeader(col, fmt.Sprintf("Logs for pod %s container %s:", t.Pod.Name, t.Container))
+		}
+
+		meta := streamMeta{Namespace: namespace, Pod: t.Pod.Name, Container: t.Container}
+		err = scanLines(stream, meta, col, opts, out, nil, nil)
+		stream.Close()
+		if err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanLines reads newline-delimited log content from stream and writes it
+// through out, one line at a time, bounding a single buffered line to
+// opts.MaxLineBytes so long structured-logger lines aren't truncated. If
+// stop is non-nil, scanning stops as soon as it's closed, once whatever was
+// already scanned has been written. If onTimestamp is non-nil, it is called
+// with each line's leading wire timestamp (if any parses), regardless of
+// whether --timestamps display is on, so callers can track how far the
+// stream has progressed.
+func scanLines(stream io.Reader, meta streamMeta, col *color.Color, opts logsOptions, out *multiLogWriter, stop <-chan struct{}, onTimestamp func(time.Time)) error {
+	maxLineBytes := opts.MaxLineBytes
+	if maxLineBytes <= 0 {
+		maxLineBytes = defaultMaxLineBytes
+	}
+
+	// bufio.Scanner treats the buffer passed to Buffer as its starting size,
+	// not just a hint, so it must itself be capped at maxLineBytes - passing
+	// a flat 64KiB here silently ignored any smaller --max-line-bytes.
+	initialBufSize := 64 * 1024
+	if maxLineBytes < initialBufSize {
+		initialBufSize = maxLineBytes
+	}
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, initialBufSize), maxLineBytes)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if onTimestamp != nil {
+			if ts, _, ok := splitTimestamp(string(line)); ok {
+				onTimestamp(ts)
+			}
+		}
+		writeFormattedLine(out, meta, col, line, opts)
+
+		if stop != nil {
+			select {
+			case <-stop:
+				return nil // already-scanned lines are flushed; stop here
+			default:
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.EOF // clean stream close; caller decides whether that's worth reconnecting
+}
+
+// writeFormattedLine dispatches to the text, json, or logfmt renderer
+// according to opts.Output.
+func writeFormattedLine(out *multiLogWriter, meta streamMeta, col *color.Color, line []byte, opts logsOptions) {
+	switch opts.Output {
+	case "json":
+		writeJSONLine(out, meta, col, line, opts)
+	case "logfmt":
+		writeLogfmtLine(out, meta, col, line, opts)
+	default:
+		writeTextLine(out, meta, col, line, opts)
+	}
+}
+
+// writeTextLine applies --timestamps reformatting and the
+// "POD x CONTAINER y: " prefix (if requested) before handing the line to
+// the shared multiLogWriter. The leading wire timestamp, if any, is always
+// split off the line first - follow mode requests it from kubelet even
+// when --timestamps display is off, so it can track reconnect progress -
+// and only re-attached to the output when the user actually asked for it.
+func writeTextLine(out *multiLogWriter, meta streamMeta, col *color.Color, line []byte, opts logsOptions) {
+	prefix := ""
+	if opts.Prefix {
+		prefix = fmt.Sprintf("POD %s CONTAINER %s: ", meta.Pod, meta.Container)
+	}
+
+	ts, rest, hasTS := splitTimestamp(string(line))
+	if !hasTS {
+		rest = string(line)
+	}
+
+	if opts.Timestamps && hasTS {
+		out.WriteLine(prefix, col, []byte(fmt.Sprintf("%s %q", formatTimestamp(ts, opts), rest)))
+		return
+	}
+
+	out.WriteLine(prefix, col, []byte(fmt.Sprintf("%q", rest)))
+}
+
+// writeJSONLine renders line as a newline-delimited JSON envelope carrying
+// the stream's identity. If line itself parses as JSON, its fields are
+// merged into the envelope under "log" rather than being double-escaped
+// into a string field.
+func writeJSONLine(out *multiLogWriter, meta streamMeta, col *color.Color, line []byte, opts logsOptions) {
+	message := string(line)
+
+	envelope := map[string]interface{}{
+		"pod":       meta.Pod,
+		"container": meta.Container,
+		"namespace": meta.Namespace,
+	}
+
+	if ts, rest, ok := splitTimestamp(message); ok {
+		message = rest
+		if opts.Timestamps {
+			envelope["timestamp"] = formatTimestamp(ts, opts)
+		}
+	}
+
+	var parsed interface{}
+	if json.Unmarshal([]byte(message), &parsed) == nil {
+		envelope["log"] = parsed
+	} else {
+		envelope["message"] = message
+	}
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%q", message))
+	}
+
+	out.WriteLine("", col, data)
+}
+
+// writeLogfmtLine renders line as a logfmt key=value record carrying the
+// stream's identity, e.g. `pod=foo container=bar namespace=default ts=... msg="..."`.
+func writeLogfmtLine(out *multiLogWriter, meta streamMeta, col *color.Color, line []byte, opts logsOptions) {
+	message := string(line)
+
+	var ts string
+	if parsed, rest, ok := splitTimestamp(message); ok {
+		message = rest
+		if opts.Timestamps {
+			ts = formatTimestamp(parsed, opts)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "pod=%s container=%s", meta.Pod, meta.Container)
+	if meta.Namespace != "" {
+		fmt.Fprintf(&b, " namespace=%s", meta.Namespace)
+	}
+	if ts != "" {
+		fmt.Fprintf(&b, " ts=%s", ts)
+	}
+	fmt.Fprintf(&b, " msg=%q", message)
+
+	out.WriteLine("", col, []byte(b.String()))
+}
 
-			defer stream.Close()
-			_, err = io.Copy(os.Stdout, stream)
+// formatTimestamp renders ts in UTC or local time depending on opts.UTC,
+// matching the --utc flag's effect on --timestamps across all output modes.
+func formatTimestamp(ts time.Time, opts logsOptions) string {
+	if opts.UTC {
+		ts = ts.UTC()
+	} else {
+		ts = ts.Local()
+	}
+	return ts.Format(time.RFC3339Nano)
+}
+
+// followLogs streams logs from every (pod, container) in initialPods, then
+// keeps watching selector for pods created or deleted afterwards, starting
+// and stopping streams accordingly. It returns once the user interrupts
+// with SIGINT or the watch itself ends, after draining in-flight lines.
+func followLogs(client *kubernetes.Clientset, namespace string, selector labels.Selector, initialPods []api.Pod, opts logsOptions, out *multiLogWriter) error {
+	podIntr := client.Pods(namespace)
+
+	watcher, err := podIntr.Watch(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	active := map[string]map[string]chan struct{}{} // pod name -> container -> stop channel
+	var wg sync.WaitGroup
+	var nextColor int32
+
+	startStream := func(pod api.Pod, container string) {
+		mu.Lock()
+		if active[pod.Name] == nil {
+			active[pod.Name] = map[string]chan struct{}{}
+		}
+		if _, exists := active[pod.Name][container]; exists {
+			mu.Unlock()
+			return
+		}
+		stop := make(chan struct{})
+		active[pod.Name][container] = stop
+		mu.Unlock()
+
+		ndx := int(atomic.AddInt32(&nextColor, 1)) - 1
+		col := streamColor(ndx, opts.UseColor)
+
+		meta := streamMeta{Namespace: namespace, Pod: pod.Name, Container: container}
+
+		podName := pod.Name
+		containerName := container
+		label := podName + "/" + containerName
+
+		// open builds a fresh PodLogOptions per connection attempt. On the
+		// first attempt (since == nil) it honors the user's --since/--tail;
+		// on a reconnect, since is the last timestamp we actually printed,
+		// so the stream resumes instead of replaying everything again.
+		open := func(since *metav1.Time) (io.ReadCloser, error) {
+			podLogOpts := &api.PodLogOptions{
+				Container:    containerName,
+				Follow:       true,
+				SinceSeconds: opts.SinceSeconds,
+				SinceTime:    opts.SinceTime,
+				Previous:     opts.Previous,
+				Timestamps:   true, // always requested in follow mode so reconnects can track progress; stripped back out in the renderers unless --timestamps was asked for
+			}
+			if since != nil {
+				podLogOpts.SinceSeconds = nil
+				podLogOpts.SinceTime = since
+			} else if opts.Tail != -1 {
+				tail := int64(opts.Tail)
+				podLogOpts.TailLines = &tail
+			}
+			return podIntr.GetLogs(podName, podLogOpts).Stream()
+		}
+
+		// podRunning reports whether containerName in podName is still
+		// running, used to tell a container that finished normally (don't
+		// reconnect) apart from a stream that merely dropped (do).
+		podRunning := func() bool {
+			p, err := podIntr.Get(podName)
 			if err != nil {
-				return err
+				return true // can't tell; let backoff/--max-reconnect bound the retries instead
+			}
+			for _, cs := range p.Status.ContainerStatuses {
+				if cs.Name == containerName {
+					return cs.State.Terminated == nil
+				}
+			}
+			return true
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			streamWithReconnect(open, label, meta, stop, col, opts, out, podRunning)
+
+			mu.Lock()
+			delete(active[podName], containerName)
+			mu.Unlock()
+		}()
+	}
+
+	stopStreams := func(podName string) {
+		mu.Lock()
+		streams := active[podName]
+		delete(active, podName)
+		mu.Unlock()
+
+		for _, stop := range streams {
+			close(stop)
+		}
+	}
+
+	for _, pod := range initialPods {
+		containers, err := resolveContainers(pod, opts)
+		if err != nil {
+			return err
+		}
+		for _, container := range containers {
+			startStream(pod, container)
+		}
+	}
+
+	watchDone := make(chan struct{})
+	go func() {
+		defer close(watchDone)
+		for event := range watcher.ResultChan() {
+			pod, ok := event.Object.(*api.Pod)
+			if !ok {
+				continue
+			}
+
+			switch event.Type {
+			case watch.Added:
+				containers, err := resolveContainers(*pod, opts)
+				if err != nil {
+					// A pod's container set doesn't change after creation, so
+					// this isn't transient: it'll never produce output. Warn
+					// once rather than silently dropping it forever.
+					fmt.Println("Skipping pod", pod.Name, "- cannot resolve containers to stream:", err)
+					continue
+				}
+				for _, container := range containers {
+					startStream(*pod, container)
+				}
+			case watch.Deleted:
+				stopStreams(pod.Name)
 			}
 		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+
+	select {
+	case <-sigCh:
+		fmt.Println("\nShutting down, draining in-flight log lines...")
+	case <-watchDone:
 	}
 
-	if follow {
-		wg.Wait()
+	watcher.Stop()
+
+	// watcher.Stop() doesn't guarantee the watch goroutine above has
+	// finished draining ResultChan - a buffered watch.Deleted for one of
+	// these same pods can still race in and call stopStreams concurrently.
+	// Deleting each entry from active in the same locked section where its
+	// channel is collected ensures only one of the two paths ever closes a
+	// given stop channel.
+	mu.Lock()
+	var stops []chan struct{}
+	for podName, streams := range active {
+		for _, stop := range streams {
+			stops = append(stops, stop)
+		}
+		delete(active, podName)
+	}
+	mu.Unlock()
+	for _, stop := range stops {
+		close(stop)
 	}
 
+	wg.Wait()
 	return nil
 }
 
-func openLogStream(stream io.ReadCloser, podName string, wg *sync.WaitGroup, col *color.Color) {
-	defer stream.Close()
-	defer wg.Done()
+// streamColor picks a distinct color for the ndx'th concurrent stream, or
+// plain white with colors disabled entirely when useColor is false.
+func streamColor(ndx int, useColor bool) *color.Color {
+	if !useColor {
+		color.NoColor = true
+		return color.New(color.FgWhite)
+	}
+	return colors[ndx%len(colors)]
+}
+
+// streamWithReconnect reads lines from the stream(s) produced by open until
+// stop is closed, reconnecting with exponential backoff on transient
+// errors. Each reconnect passes open the timestamp of the last line
+// actually printed, so it resumes rather than replaying history. podRunning
+// is consulted on a clean stream close to tell a container that simply
+// finished (don't reconnect) apart from a dropped connection (do). It gives
+// up and returns once a non-transient read error occurs, stop is closed and
+// fully drained, or --max-reconnect attempts are exhausted. Errors from open
+// itself are retried whatever their kind.
+func streamWithReconnect(open func(since *metav1.Time) (io.ReadCloser, error), label string, meta streamMeta, stop <-chan struct{}, col *color.Color, opts logsOptions, out *multiLogWriter, podRunning func() bool) {
+	backoff := time.Second
+	attempts := 0
+	var cursor *metav1.Time
 
-	buf := bufio.NewReader(stream)
 	for {
-		line, _, err := buf.ReadLine()
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		stream, err := open(cursor)
 		if err != nil {
-			fmt.Println("Error from routine for", podName, ":", err)
+			fmt.Println("Error opening log stream for", label, ":", err)
+			if !waitForReconnect(stop, &backoff, &attempts, opts.MaxReconnect) {
+				return
+			}
+			continue
+		}
+
+		// Closing the stream is what actually unblocks a goroutine parked in
+		// scanLines's Read once stop fires - the stop check between Scan
+		// calls never runs on an idle stream with no lines arriving.
+		var closeOnce sync.Once
+		closeStream := func() { closeOnce.Do(func() { stream.Close() }) }
+		unblock := make(chan struct{})
+		go func() {
+			select {
+			case <-stop:
+				closeStream()
+			case <-unblock:
+			}
+		}()
+
+		var lastSeen time.Time
+		var sawLine bool
+		readErr := scanLines(stream, meta, col, opts, out, stop, func(ts time.Time) {
+			lastSeen = ts
+			sawLine = true
+		})
+		close(unblock)
+		closeStream()
+
+		if sawLine {
+			advanced := metav1.NewTime(lastSeen.Add(time.Nanosecond))
+			cursor = &advanced
+		}
+
+		select {
+		case <-stop:
+			return // shutdown requested; whatever scanLines already flushed is enough
+		default:
+		}
+
+		if readErr == nil {
+			return // scanLines returned cleanly without stop firing: not expected, but nothing left to do
+		}
+
+		if readErr == io.EOF {
+			// A container that exited normally closes its log stream the
+			// same way a dropped connection does, so ask the API which one
+			// actually happened before deciding to reconnect.
+			if podRunning != nil && !podRunning() {
+				return
+			}
+		} else if !isTransient(readErr) {
+			fmt.Println("Error from routine for", label, ":", readErr)
+			return
+		}
+
+		if !waitForReconnect(stop, &backoff, &attempts, opts.MaxReconnect) {
 			return
 		}
+	}
+}
+
+// isTransient reports whether err is the kind of stream error that's worth
+// reconnecting for, rather than a permanent failure. A clean io.EOF is
+// handled separately by the caller, since whether it's worth reconnecting
+// depends on whether the container is still running.
+func isTransient(err error) bool {
+	if err == io.ErrUnexpectedEOF {
+		return true
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "connection reset") ||
+		strings.Contains(msg, "broken pipe") ||
+		strings.Contains(msg, "use of closed network connection")
+}
 
-		col.Printf("POD %s: ", podName)
-		fmt.Printf("%q\n", line)
+// waitForReconnect sleeps for the current backoff (doubling it, capped at
+// 30s) unless stop fires first or maxReconnect attempts have been used.
+// maxReconnect of 0 means unlimited attempts.
+func waitForReconnect(stop <-chan struct{}, backoff *time.Duration, attempts *int, maxReconnect int) bool {
+	*attempts++
+	if maxReconnect > 0 && *attempts > maxReconnect {
+		return false
 	}
+
+	select {
+	case <-stop:
+		return false
+	case <-time.After(*backoff):
+	}
+
+	*backoff *= 2
+	if *backoff > 30*time.Second {
+		*backoff = 30 * time.Second
+	}
+
+	return true
+}
+
+// splitTimestamp splits the leading RFC3339Nano timestamp that kubelet
+// prefixes onto each log line (when PodLogOptions.Timestamps is set) from
+// the rest of the line. ok is false if line didn't start with a timestamp.
+func splitTimestamp(line string) (ts time.Time, rest string, ok bool) {
+	parts := strings.SplitN(line, " ", 2)
+	if len(parts) != 2 {
+		return time.Time{}, line, false
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, line, false
+	}
+
+	return ts, parts[1], true
 }
 
 func init() {
@@ -181,4 +845,15 @@ func init() {
 	logsCmd.Flags().StringVarP(&containerFlag, "container", "c", "", "Print the logs of this container")
 	logsCmd.Flags().IntVarP(&tailFlag, "tail", "t", -1, "Lines of recent log file to display. Defaults to -1, showing all log lines.")
 	logsCmd.Flags().BoolVarP(&followFlag, "follow", "f", false, "Specify if the logs should be streamed.")
+	logsCmd.Flags().DurationVar(&sinceFlag, "since", 0, "Only return logs newer than a relative duration like 5s, 2m, or 3h.")
+	logsCmd.Flags().StringVar(&sinceTimeFlag, "since-time", "", "Only return logs after a specific date (RFC3339). Mutually exclusive with --since.")
+	logsCmd.Flags().BoolVarP(&timestampsFlag, "timestamps", "T", false, "Include timestamps on each line in the log output.")
+	logsCmd.Flags().BoolVarP(&previousFlag, "previous", "p", false, "Print the logs for the previous instance of the container in a pod if it exists.")
+	logsCmd.Flags().BoolVar(&utcFlag, "utc", false, "Render --timestamps in UTC instead of local time.")
+	logsCmd.Flags().BoolVar(&allContainersFlag, "all-containers", false, "Stream logs from all containers of every matched pod. Ignored if --container is set.")
+	logsCmd.Flags().BoolVar(&prefixFlag, "prefix", true, "Prefix each line with the source pod and container. Set to false for machine-consumable piping.")
+	logsCmd.Flags().BoolVar(&namesOnlyFlag, "names-only", false, "Only list the matched pod/container streams, without fetching their logs.")
+	logsCmd.Flags().IntVar(&maxReconnectFlag, "max-reconnect", 0, "Maximum reconnect attempts per stream after a transient error in follow mode. 0 means unlimited.")
+	logsCmd.Flags().IntVar(&maxLineBytesFlag, "max-line-bytes", defaultMaxLineBytes, "Largest single log line to buffer, in bytes. A longer line ends that stream with an error.")
+	logsCmd.Flags().StringVarP(&outputFlag, "output", "o", "text", "Output format: text, json, or logfmt.")
 }
@@ -0,0 +1,145 @@
+// Copyright © 2016 Apigee Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func newTestWriter() (*multiLogWriter, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return newMultiLogWriter(buf), buf
+}
+
+func TestWriteJSONLinePlainMessage(t *testing.T) {
+	color.NoColor = true
+	out, buf := newTestWriter()
+	meta := streamMeta{Namespace: "default", Pod: "web-1", Container: "app"}
+
+	writeJSONLine(out, meta, color.New(color.FgWhite), []byte("booting up"), logsOptions{Output: "json"})
+
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &envelope); err != nil {
+		t.Fatalf("output is not valid JSON: %v (%s)", err, buf.String())
+	}
+	if envelope["pod"] != "web-1" || envelope["container"] != "app" || envelope["namespace"] != "default" {
+		t.Errorf("envelope identity fields wrong: %+v", envelope)
+	}
+	if envelope["message"] != "booting up" {
+		t.Errorf("envelope[\"message\"] = %v, want %q", envelope["message"], "booting up")
+	}
+	if _, hasLog := envelope["log"]; hasLog {
+		t.Errorf("plain-text line should not produce a \"log\" field: %+v", envelope)
+	}
+}
+
+func TestWriteJSONLineMergesStructuredLog(t *testing.T) {
+	color.NoColor = true
+	out, buf := newTestWriter()
+	meta := streamMeta{Pod: "web-1", Container: "app"}
+
+	writeJSONLine(out, meta, color.New(color.FgWhite), []byte(`{"level":"info","msg":"ready"}`), logsOptions{Output: "json"})
+
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &envelope); err != nil {
+		t.Fatalf("output is not valid JSON: %v (%s)", err, buf.String())
+	}
+	if _, hasMessage := envelope["message"]; hasMessage {
+		t.Errorf("structured line should not double-escape into \"message\": %+v", envelope)
+	}
+	log, ok := envelope["log"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("envelope[\"log\"] = %v, want a merged object", envelope["log"])
+	}
+	if log["level"] != "info" || log["msg"] != "ready" {
+		t.Errorf("merged log fields wrong: %+v", log)
+	}
+}
+
+func TestWriteJSONLineStripsTimestampWhenRequested(t *testing.T) {
+	color.NoColor = true
+	out, buf := newTestWriter()
+	meta := streamMeta{Pod: "web-1", Container: "app"}
+	line := []byte("2016-10-06T00:17:09.669794202Z booting up")
+
+	writeJSONLine(out, meta, color.New(color.FgWhite), line, logsOptions{Output: "json", Timestamps: true})
+
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &envelope); err != nil {
+		t.Fatalf("output is not valid JSON: %v (%s)", err, buf.String())
+	}
+	if envelope["message"] != "booting up" {
+		t.Errorf("envelope[\"message\"] = %v, want the timestamp stripped off", envelope["message"])
+	}
+	if envelope["timestamp"] == nil {
+		t.Errorf("envelope should carry \"timestamp\" when --timestamps is set")
+	}
+}
+
+func TestWriteJSONLineOmitsTimestampWhenNotRequested(t *testing.T) {
+	color.NoColor = true
+	out, buf := newTestWriter()
+	meta := streamMeta{Pod: "web-1", Container: "app"}
+	line := []byte("2016-10-06T00:17:09.669794202Z booting up")
+
+	writeJSONLine(out, meta, color.New(color.FgWhite), line, logsOptions{Output: "json"})
+
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &envelope); err != nil {
+		t.Fatalf("output is not valid JSON: %v (%s)", err, buf.String())
+	}
+	if _, hasTS := envelope["timestamp"]; hasTS {
+		t.Errorf("envelope should not carry \"timestamp\" when --timestamps is off: %+v", envelope)
+	}
+	if envelope["message"] != "booting up" {
+		t.Errorf("wire timestamp should still be stripped from message even when not displayed, got %v", envelope["message"])
+	}
+}
+
+func TestWriteLogfmtLine(t *testing.T) {
+	color.NoColor = true
+	out, buf := newTestWriter()
+	meta := streamMeta{Namespace: "default", Pod: "web-1", Container: "app"}
+
+	writeLogfmtLine(out, meta, color.New(color.FgWhite), []byte("booting up"), logsOptions{Output: "logfmt"})
+
+	got := strings.TrimRight(buf.String(), "\n")
+	want := `pod=web-1 container=app namespace=default msg="booting up"`
+	if got != want {
+		t.Errorf("writeLogfmtLine output = %q, want %q", got, want)
+	}
+}
+
+func TestWriteLogfmtLineWithTimestamp(t *testing.T) {
+	color.NoColor = true
+	out, buf := newTestWriter()
+	meta := streamMeta{Pod: "web-1", Container: "app"}
+	line := []byte("2016-10-06T00:17:09.669794202Z booting up")
+
+	writeLogfmtLine(out, meta, color.New(color.FgWhite), line, logsOptions{Output: "logfmt", Timestamps: true, UTC: true})
+
+	got := strings.TrimRight(buf.String(), "\n")
+	if !strings.Contains(got, "ts=2016-10-06T00:17:09.669794202Z") {
+		t.Errorf("writeLogfmtLine output = %q, want a ts= field with the UTC timestamp", got)
+	}
+	if !strings.HasSuffix(got, `msg="booting up"`) {
+		t.Errorf("writeLogfmtLine output = %q, want the timestamp stripped out of msg", got)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright © 2016 Apigee Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	api "k8s.io/client-go/pkg/api/v1"
+)
+
+func TestSplitTimestamp(t *testing.T) {
+	cases := []struct {
+		name    string
+		line    string
+		wantOk  bool
+		wantTS  string
+		wantMsg string
+	}{
+		{
+			name:    "valid RFC3339Nano prefix",
+			line:    "2016-10-06T00:17:09.669794202Z hello world",
+			wantOk:  true,
+			wantTS:  "2016-10-06T00:17:09.669794202Z",
+			wantMsg: "hello world",
+		},
+		{
+			name:   "no timestamp prefix",
+			line:   "hello world",
+			wantOk: false,
+		},
+		{
+			name:   "empty line",
+			line:   "",
+			wantOk: false,
+		},
+		{
+			name:   "malformed timestamp",
+			line:   "not-a-timestamp hello",
+			wantOk: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ts, rest, ok := splitTimestamp(c.line)
+			if ok != c.wantOk {
+				t.Fatalf("splitTimestamp(%q) ok = %v, want %v", c.line, ok, c.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if rest != c.wantMsg {
+				t.Errorf("splitTimestamp(%q) rest = %q, want %q", c.line, rest, c.wantMsg)
+			}
+			if got := ts.Format(time.RFC3339Nano); got != c.wantTS {
+				t.Errorf("splitTimestamp(%q) ts = %q, want %q", c.line, got, c.wantTS)
+			}
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts, err := time.Parse(time.RFC3339, "2016-10-06T00:17:09Z")
+	if err != nil {
+		t.Fatalf("parsing fixture time: %v", err)
+	}
+
+	if got := formatTimestamp(ts, logsOptions{UTC: true}); got != "2016-10-06T00:17:09Z" {
+		t.Errorf("UTC formatTimestamp = %q, want %q", got, "2016-10-06T00:17:09Z")
+	}
+
+	local := formatTimestamp(ts, logsOptions{UTC: false})
+	if parsed, err := time.Parse(time.RFC3339Nano, local); err != nil || !parsed.Equal(ts) {
+		t.Errorf("local formatTimestamp = %q, does not round-trip to the same instant", local)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"connection reset", errors.New("read tcp 127.0.0.1:443: connection reset by peer"), true},
+		{"broken pipe", errors.New("write: broken pipe"), true},
+		{"closed network connection", errors.New("use of closed network connection"), true},
+		{"permanent error", errors.New("pods \"foo\" not found"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isTransient(c.err); got != c.want {
+				t.Errorf("isTransient(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWaitForReconnectCapsBackoff(t *testing.T) {
+	stop := make(chan struct{})
+	backoff := 20 * time.Second
+	attempts := 0
+
+	if !waitForReconnect(stop, &backoff, &attempts, 0) {
+		t.Fatalf("waitForReconnect returned false with unlimited reconnects")
+	}
+	if backoff != 30*time.Second {
+		t.Errorf("backoff = %v, want capped at 30s", backoff)
+	}
+}
+
+func TestWaitForReconnectRespectsMaxReconnect(t *testing.T) {
+	stop := make(chan struct{})
+	backoff := time.Millisecond
+	attempts := 0
+
+	if !waitForReconnect(stop, &backoff, &attempts, 1) {
+		t.Fatalf("first attempt should be allowed")
+	}
+	if waitForReconnect(stop, &backoff, &attempts, 1) {
+		t.Fatalf("second attempt should be refused once max-reconnect is exhausted")
+	}
+}
+
+func TestWaitForReconnectStopsOnStop(t *testing.T) {
+	stop := make(chan struct{})
+	close(stop)
+	backoff := time.Hour
+	attempts := 0
+
+	if waitForReconnect(stop, &backoff, &attempts, 0) {
+		t.Fatalf("waitForReconnect should return false once stop is closed")
+	}
+}
+
+func TestResolveContainers(t *testing.T) {
+	podWithOne := api.Pod{}
+	podWithOne.Name = "single"
+	podWithOne.Spec.Containers = []api.Container{{Name: "app"}}
+
+	podWithMany := api.Pod{}
+	podWithMany.Name = "multi"
+	podWithMany.Spec.Containers = []api.Container{{Name: "app"}, {Name: "sidecar"}}
+
+	cases := []struct {
+		name    string
+		pod     api.Pod
+		opts    logsOptions
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single container pod, no flags needed",
+			pod:  podWithOne,
+			opts: logsOptions{},
+			want: []string{"app"},
+		},
+		{
+			name: "explicit container wins regardless of pod shape",
+			pod:  podWithMany,
+			opts: logsOptions{Container: "sidecar"},
+			want: []string{"sidecar"},
+		},
+		{
+			name: "all-containers streams every container",
+			pod:  podWithMany,
+			opts: logsOptions{AllContainers: true},
+			want: []string{"app", "sidecar"},
+		},
+		{
+			name:    "multi-container pod with no selection is ambiguous",
+			pod:     podWithMany,
+			opts:    logsOptions{},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := resolveContainers(c.pod, c.opts)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("resolveContainers() expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveContainers() unexpected error: %v", err)
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("resolveContainers() = %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Errorf("resolveContainers()[%d] = %q, want %q", i, got[i], c.want[i])
+				}
+			}
+		})
+	}
+}